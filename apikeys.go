@@ -0,0 +1,137 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// generateAPIKey returns a random 32-byte key hex-encoded for transport in
+// an Authorization header.
+func generateAPIKey() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand failing means the system RNG is broken
+	}
+	return hex.EncodeToString(b)
+}
+
+// ErrAPIKeyNotFound is returned when a key id has no matching record.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKey is a single issued credential.
+type APIKey struct {
+	ID      string `json:"id"`
+	Key     string `json:"key"`
+	Admin   bool   `json:"admin"`
+	Revoked bool   `json:"revoked"`
+}
+
+// APIKeyStore manages issued API keys. It is implemented by a JSON file
+// store and a SQL store, selected the same way JokeStore is.
+type APIKeyStore interface {
+	// Lookup finds the key record for a raw key value. It returns
+	// ErrAPIKeyNotFound for unknown or revoked keys.
+	Lookup(key string) (APIKey, error)
+	// Create issues and persists a new key, returning the stored record.
+	Create(id string, admin bool) (APIKey, error)
+	// Revoke marks a key as revoked so Lookup stops returning it.
+	Revoke(id string) error
+	// Bootstrap ensures a key record with the given raw key value exists,
+	// creating it exactly as given if it's missing and leaving an existing
+	// record untouched otherwise. It exists so a fresh deploy can be seeded
+	// with a first admin key (see ADMIN_BOOTSTRAP_KEY) without needing an
+	// already-valid admin key to call POST /admin/keys.
+	Bootstrap(key APIKey) error
+}
+
+// FileAPIKeyStore keeps API keys in a JSON file, mirroring JSONFileStore's
+// load-then-flush-on-mutation approach.
+type FileAPIKeyStore struct {
+	mu   sync.RWMutex
+	path string
+	keys map[string]APIKey // keyed by raw key value
+}
+
+// NewFileAPIKeyStore loads keys from path, creating an empty file if it does
+// not yet exist.
+func NewFileAPIKeyStore(path string) (*FileAPIKeyStore, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		s := &FileAPIKeyStore{path: path, keys: map[string]APIKey{}}
+		return s, s.flush()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	s := &FileAPIKeyStore{path: path, keys: map[string]APIKey{}}
+	for _, k := range keys {
+		s.keys[k.Key] = k
+	}
+	return s, nil
+}
+
+func (s *FileAPIKeyStore) flush() error {
+	keys := make([]APIKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		keys = append(keys, k)
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+func (s *FileAPIKeyStore) Lookup(key string) (APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.keys[key]
+	if !ok || k.Revoked {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	return k, nil
+}
+
+func (s *FileAPIKeyStore) Create(id string, admin bool) (APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := APIKey{ID: id, Key: generateAPIKey(), Admin: admin}
+	s.keys[k.Key] = k
+	return k, s.flush()
+}
+
+func (s *FileAPIKeyStore) Bootstrap(key APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key.Key]; ok {
+		return nil
+	}
+	s.keys[key.Key] = key
+	return s.flush()
+}
+
+func (s *FileAPIKeyStore) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, k := range s.keys {
+		if k.ID == id {
+			k.Revoked = true
+			s.keys[key] = k
+			return s.flush()
+		}
+	}
+	return ErrAPIKeyNotFound
+}
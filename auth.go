@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// rateLimitHitsTotal and apiKeyRequestsTotal feed the per-key quota
+// dashboards operators use to see who is consuming how much of the API.
+var (
+	rateLimitHitsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rate_limit_hits_total",
+			Help: "Total number of requests rejected by the rate limiter, labeled by key id and endpoint.",
+		},
+		[]string{"key_id", "endpoint"},
+	)
+
+	apiKeyRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "api_key_requests_total",
+			Help: "Total number of authenticated requests, labeled by key id.",
+		},
+		[]string{"key_id"},
+	)
+)
+
+// RateLimitConfig is the YAML shape for configuring token-bucket limits,
+// loaded once at startup.
+type RateLimitConfig struct {
+	Default RateLimit            `yaml:"default"`
+	PerKey  map[string]RateLimit `yaml:"per_key"`
+	PerIP   RateLimit            `yaml:"per_ip"`
+}
+
+// RateLimit describes a token bucket: rps tokens refill per second, up to
+// burst tokens banked.
+type RateLimit struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+// defaultRateLimitConfig is used when no config file is present, so the
+// server still enforces sane limits out of the box.
+var defaultRateLimitConfig = RateLimitConfig{
+	Default: RateLimit{RPS: 5, Burst: 10},
+	PerIP:   RateLimit{RPS: 10, Burst: 20},
+}
+
+// loadRateLimitConfig reads YAML from RATE_LIMIT_CONFIG (default
+// "ratelimit.yaml"), falling back to defaultRateLimitConfig if the file does
+// not exist.
+func loadRateLimitConfig() (RateLimitConfig, error) {
+	path := os.Getenv("RATE_LIMIT_CONFIG")
+	if path == "" {
+		path = "ratelimit.yaml"
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return defaultRateLimitConfig, nil
+	}
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	var cfg RateLimitConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return RateLimitConfig{}, err
+	}
+	return cfg, nil
+}
+
+// limiterBucketTTL is how long a bucket may sit idle before it is evicted.
+// IP buckets in particular would otherwise grow without bound as new
+// callers show up over the life of the process.
+const limiterBucketTTL = 30 * time.Minute
+
+// limiterEntry pairs a token bucket with the last time it was touched, so
+// idle buckets can be evicted.
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter hands out a token-bucket limiter per key id (or per IP for
+// unauthenticated callers), lazily created from RateLimitConfig. Buckets
+// idle for longer than limiterBucketTTL are evicted by a background
+// goroutine so the map doesn't grow forever as new IPs/keys are seen.
+type rateLimiter struct {
+	cfg      RateLimitConfig
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
+	rl := &rateLimiter{cfg: cfg, limiters: map[string]*limiterEntry{}}
+	go rl.evictStale()
+	return rl
+}
+
+func (rl *rateLimiter) allow(bucket string, limit RateLimit) bool {
+	rl.mu.Lock()
+	entry, ok := rl.limiters[bucket]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(limit.RPS), limit.Burst)}
+		rl.limiters[bucket] = entry
+	}
+	entry.lastSeen = time.Now()
+	limiter := entry.limiter
+	rl.mu.Unlock()
+	return limiter.Allow()
+}
+
+// evictStale periodically removes buckets that haven't been touched within
+// limiterBucketTTL. It runs for the lifetime of the process.
+func (rl *rateLimiter) evictStale() {
+	ticker := time.NewTicker(limiterBucketTTL)
+	defer ticker.Stop()
+	for range ticker.C {
+		cutoff := time.Now().Add(-limiterBucketTTL)
+		rl.mu.Lock()
+		for bucket, entry := range rl.limiters {
+			if entry.lastSeen.Before(cutoff) {
+				delete(rl.limiters, bucket)
+			}
+		}
+		rl.mu.Unlock()
+	}
+}
+
+func (rl *rateLimiter) limitFor(keyID string) RateLimit {
+	if limit, ok := rl.cfg.PerKey[keyID]; ok {
+		return limit
+	}
+	return rl.cfg.Default
+}
+
+// authContextKey is the context key the auth middleware stores the resolved
+// APIKey under.
+type authContextKey struct{}
+
+// authMiddleware validates "Authorization: Bearer <key>" or "X-API-Key",
+// enforces the per-key/per-IP token bucket, and records quota metrics.
+// Requests without a key are rejected; anonymous per-IP-only access can be
+// layered in front of this middleware if ever needed.
+func (a *jokeAPI) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawKey := bearerToken(r)
+		if rawKey == "" {
+			rawKey = r.Header.Get("X-API-Key")
+		}
+		if rawKey == "" {
+			writeError(w, http.StatusUnauthorized, "missing API key")
+			return
+		}
+
+		key, err := a.apiKeys.Lookup(rawKey)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		endpoint := endpointLabel(r)
+		if !a.limiter.allow(key.ID, a.limiter.limitFor(key.ID)) {
+			rateLimitHitsTotal.WithLabelValues(key.ID, endpoint).Inc()
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		if !a.limiter.allow("ip:"+clientIP(r), a.limiter.cfg.PerIP) {
+			rateLimitHitsTotal.WithLabelValues(key.ID, endpoint).Inc()
+			writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+
+		apiKeyRequestsTotal.WithLabelValues(key.ID).Inc()
+
+		ctx := context.WithValue(r.Context(), authContextKey{}, key)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// clientIP returns the caller's address with any ephemeral port stripped, so
+// repeat requests from the same client land in the same rate-limit bucket.
+// It honors X-Forwarded-For (first, left-most address) when the service
+// sits behind a proxy, falling back to r.RemoteAddr.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		if addr := strings.TrimSpace(strings.Split(fwd, ",")[0]); addr != "" {
+			return addr
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>"
+// header, returning "" if the header is absent or malformed.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if len(h) > len(prefix) && h[:len(prefix)] == prefix {
+		return h[len(prefix):]
+	}
+	return ""
+}
+
+// requireAdmin rejects any request whose resolved APIKey is not an admin
+// key. It must run after authMiddleware.
+func requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _ := r.Context().Value(authContextKey{}).(APIKey)
+		if !key.Admin {
+			writeError(w, http.StatusForbidden, "admin API key required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// createAPIKeyRequest is the payload for POST /admin/keys.
+type createAPIKeyRequest struct {
+	ID    string `json:"id"`
+	Admin bool   `json:"admin"`
+}
+
+// createAPIKey handles POST /admin/keys.
+func (a *jokeAPI) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req createAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		writeError(w, http.StatusBadRequest, "id is required")
+		return
+	}
+
+	key, err := a.apiKeys.Create(req.ID, req.Admin)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not create API key")
+		return
+	}
+	writeJSON(w, http.StatusCreated, key)
+}
+
+// revokeAPIKey handles DELETE /admin/keys/{id}.
+func (a *jokeAPI) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := a.apiKeys.Revoke(id); err != nil {
+		if err == ErrAPIKeyNotFound {
+			writeError(w, http.StatusNotFound, "API key not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "could not revoke API key")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// registerAdminRoutes wires the admin-only key management endpoints, guarded
+// by authMiddleware + requireAdmin so only an admin key can call them.
+func (a *jokeAPI) registerAdminRoutes(router *mux.Router) {
+	admin := router.PathPrefix("/admin").Subrouter()
+	admin.Use(a.authMiddleware, requireAdmin)
+	admin.HandleFunc("/keys", a.createAPIKey).Methods("POST")
+	admin.HandleFunc("/keys/{id}", a.revokeAPIKey).Methods("DELETE")
+}
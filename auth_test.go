@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRateLimiterAllowDeny(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{})
+	limit := RateLimit{RPS: 1, Burst: 2}
+
+	if !rl.allow("bucket", limit) {
+		t.Fatal("first request should be allowed")
+	}
+	if !rl.allow("bucket", limit) {
+		t.Fatal("second request within burst should be allowed")
+	}
+	if rl.allow("bucket", limit) {
+		t.Fatal("third request beyond burst should be denied")
+	}
+
+	// A different bucket has its own independent allowance.
+	if !rl.allow("other-bucket", limit) {
+		t.Fatal("a different bucket should not be affected by another bucket's usage")
+	}
+}
+
+func TestRateLimiterLimitFor(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{
+		Default: RateLimit{RPS: 5, Burst: 10},
+		PerKey:  map[string]RateLimit{"vip": {RPS: 50, Burst: 100}},
+	})
+
+	if got := rl.limitFor("vip"); got.RPS != 50 {
+		t.Errorf("limitFor(vip) = %+v, want per-key override", got)
+	}
+	if got := rl.limitFor("unknown"); got.RPS != 5 {
+		t.Errorf("limitFor(unknown) = %+v, want default", got)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{
+			name:       "strips ephemeral port",
+			remoteAddr: "203.0.113.5:54821",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "prefers X-Forwarded-For",
+			remoteAddr: "10.0.0.1:12345",
+			forwarded:  "198.51.100.9, 10.0.0.1",
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "falls back to RemoteAddr without a port",
+			remoteAddr: "203.0.113.5",
+			want:       "203.0.113.5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}}
+			if tt.forwarded != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := clientIP(r); got != tt.want {
+				t.Errorf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
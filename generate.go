@@ -0,0 +1,7 @@
+package main
+
+// Regenerate the gRPC/Protobuf bindings with `go generate ./...`. Generated
+// files land in proto/jokev1 and are checked in so `go build` works on a
+// fresh checkout without protoc installed; run this and commit the result
+// after editing proto/joke.proto.
+//go:generate protoc --go_out=. --go_opt=module=github.com/vikaskrishnia/joke-api --go-grpc_out=. --go-grpc_opt=module=github.com/vikaskrishnia/joke-api proto/joke.proto
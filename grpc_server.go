@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+
+	jokev1 "github.com/vikaskrishnia/joke-api/proto/jokev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// serveGRPC listens on port and blocks serving the JokeService until the
+// listener fails. Run it in its own goroutine alongside the HTTP server.
+func serveGRPC(api *jokeAPI, port string) {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("grpc: failed to listen on %s: %v", port, err)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(api.authInterceptor))
+	jokev1.RegisterJokeServiceServer(srv, newGRPCJokeServer(api))
+
+	log.Printf("gRPC server starting on port %s", port)
+	if err := srv.Serve(lis); err != nil {
+		log.Fatalf("grpc: serve failed: %v", err)
+	}
+}
+
+// authInterceptor mirrors authMiddleware's API-key lookup and per-key/per-IP
+// rate limiting for the gRPC transport, so GetRandomJoke/GetJoke/ListJokes
+// over port 9090 aren't reachable without a key or exempt from quota.
+func (a *jokeAPI) authInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	rawKey := apiKeyFromMetadata(ctx)
+	if rawKey == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing API key")
+	}
+
+	key, err := a.apiKeys.Lookup(rawKey)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	if !a.limiter.allow(key.ID, a.limiter.limitFor(key.ID)) {
+		rateLimitHitsTotal.WithLabelValues(key.ID, info.FullMethod).Inc()
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	if !a.limiter.allow("ip:"+peerAddr(ctx), a.limiter.cfg.PerIP) {
+		rateLimitHitsTotal.WithLabelValues(key.ID, info.FullMethod).Inc()
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	apiKeyRequestsTotal.WithLabelValues(key.ID).Inc()
+
+	return handler(ctx, req)
+}
+
+// apiKeyFromMetadata extracts the caller's API key from incoming gRPC
+// metadata, checking "authorization: Bearer <key>" then "x-api-key" to match
+// the HTTP transport's Authorization/X-API-Key header precedence.
+func apiKeyFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	const prefix = "Bearer "
+	if vals := md.Get("authorization"); len(vals) > 0 {
+		if h := vals[0]; len(h) > len(prefix) && h[:len(prefix)] == prefix {
+			return h[len(prefix):]
+		}
+	}
+	if vals := md.Get("x-api-key"); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// peerAddr returns the caller's address with any ephemeral port stripped,
+// mirroring clientIP's bucketing for the HTTP transport.
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// grpcJokeServer adapts a jokeAPI to the generated JokeService interface so
+// the gRPC transport resolves joke text through the same
+// store/Translator path as the HTTP handlers, rather than duplicating that
+// logic.
+type grpcJokeServer struct {
+	jokev1.UnimplementedJokeServiceServer
+	api *jokeAPI
+}
+
+func newGRPCJokeServer(api *jokeAPI) *grpcJokeServer {
+	return &grpcJokeServer{api: api}
+}
+
+func (s *grpcJokeServer) GetRandomJoke(ctx context.Context, req *jokev1.JokeRequest) (*jokev1.JokeResponse, error) {
+	lang := req.GetLang()
+	if lang == "" {
+		lang = "en"
+	}
+
+	joke, err := s.api.store.Random()
+	if errors.Is(err, ErrJokeNotFound) {
+		return nil, status.Errorf(codes.NotFound, "joke not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not fetch a joke: %v", err)
+	}
+
+	text, lang := s.api.resolveJokeTextOrFallback(ctx, joke, lang)
+	return &jokev1.JokeResponse{Joke: text, Language: lang}, nil
+}
+
+func (s *grpcJokeServer) GetJoke(ctx context.Context, req *jokev1.JokeRequest) (*jokev1.Joke, error) {
+	joke, err := s.api.store.Get(int(req.GetId()))
+	if errors.Is(err, ErrJokeNotFound) {
+		return nil, status.Errorf(codes.NotFound, "joke %d not found", req.GetId())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not fetch joke: %v", err)
+	}
+	return jokeToProto(joke), nil
+}
+
+func (s *grpcJokeServer) ListJokes(ctx context.Context, req *jokev1.ListJokesRequest) (*jokev1.ListJokesResponse, error) {
+	var (
+		jokes []Joke
+		err   error
+	)
+	if category := req.GetCategory(); category != "" {
+		jokes, err = s.api.store.ByCategory(category)
+	} else {
+		jokes, err = s.api.store.List()
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not list jokes: %v", err)
+	}
+
+	out := make([]*jokev1.Joke, 0, len(jokes))
+	for _, j := range jokes {
+		out = append(out, jokeToProto(j))
+	}
+	return &jokev1.ListJokesResponse{Jokes: out}, nil
+}
+
+// jokeToProto converts a domain Joke into its wire representation, shared by
+// the gRPC server and the protojson content-negotiation path over HTTP.
+func jokeToProto(j Joke) *jokev1.Joke {
+	return &jokev1.Joke{
+		Id:       int32(j.ID),
+		Content:  j.Content,
+		Category: j.Category,
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// jokeAPI holds the dependencies needed by the joke HTTP handlers.
+type jokeAPI struct {
+	store      JokeStore
+	translator Translator
+	apiKeys    APIKeyStore
+	limiter    *rateLimiter
+}
+
+// supportedLangs lists the languages jokes may carry content for.
+var supportedLangs = map[string]bool{
+	"en": true,
+	"es": true,
+	"fr": true,
+	"de": true,
+	"hi": true, // Added Hindi support
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, ErrorResponse{Error: msg})
+}
+
+// registerRoutes wires every joke endpoint onto router, behind authMiddleware
+// so each call is attributed to an API key and subject to its rate limit.
+func (a *jokeAPI) registerRoutes(router *mux.Router) {
+	api := router.NewRoute().Subrouter()
+	api.Use(a.authMiddleware)
+
+	api.HandleFunc("/joke", a.getRandomJoke).Methods("GET")
+	api.HandleFunc("/jokes", a.listJokes).Methods("GET")
+	api.HandleFunc("/jokes", a.createJoke).Methods("POST")
+	api.HandleFunc("/jokes/{id}", a.getJoke).Methods("GET")
+	api.HandleFunc("/jokes/{id}", a.updateJoke).Methods("PUT")
+	api.HandleFunc("/jokes/{id}", a.deleteJoke).Methods("DELETE")
+	api.HandleFunc("/categories/{cat}", a.getByCategory).Methods("GET")
+	api.HandleFunc("/languages", a.getLanguages).Methods("GET")
+}
+
+// getRandomJoke returns a random joke in the requested language, invoking the
+// configured Translator on-demand when the joke lacks stored content for it.
+func (a *jokeAPI) getRandomJoke(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		lang = "en" // Default to English
+	}
+
+	randomJoke, err := a.store.Random()
+	if errors.Is(err, ErrJokeNotFound) {
+		writeError(w, http.StatusNotFound, "joke not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not fetch a joke")
+		return
+	}
+
+	jokeText, lang := a.resolveJokeTextOrFallback(r.Context(), randomJoke, lang)
+
+	writeJokeResponse(w, r, http.StatusOK, JokeResponse{Joke: jokeText, Language: lang})
+}
+
+func (a *jokeAPI) listJokes(w http.ResponseWriter, r *http.Request) {
+	jokes, err := a.store.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not list jokes")
+		return
+	}
+	writeJokeList(w, r, http.StatusOK, jokes)
+}
+
+func (a *jokeAPI) getJoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id must be an integer")
+		return
+	}
+
+	joke, err := a.store.Get(id)
+	if errors.Is(err, ErrJokeNotFound) {
+		writeError(w, http.StatusNotFound, "joke not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not fetch joke")
+		return
+	}
+	writeJoke(w, r, http.StatusOK, joke)
+}
+
+func (a *jokeAPI) createJoke(w http.ResponseWriter, r *http.Request) {
+	var j Joke
+	if err := json.NewDecoder(r.Body).Decode(&j); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid joke payload")
+		return
+	}
+
+	created, err := a.store.Add(j)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJoke(w, r, http.StatusCreated, created)
+}
+
+func (a *jokeAPI) updateJoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id must be an integer")
+		return
+	}
+
+	var j Joke
+	if err := json.NewDecoder(r.Body).Decode(&j); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid joke payload")
+		return
+	}
+
+	updated, err := a.store.Update(id, j)
+	if errors.Is(err, ErrJokeNotFound) {
+		writeError(w, http.StatusNotFound, "joke not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJoke(w, r, http.StatusOK, updated)
+}
+
+func (a *jokeAPI) deleteJoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id must be an integer")
+		return
+	}
+
+	err = a.store.Delete(id)
+	if errors.Is(err, ErrJokeNotFound) {
+		writeError(w, http.StatusNotFound, "joke not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not delete joke")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *jokeAPI) getByCategory(w http.ResponseWriter, r *http.Request) {
+	category := mux.Vars(r)["cat"]
+
+	jokes, err := a.store.ByCategory(category)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not fetch category")
+		return
+	}
+	if len(jokes) == 0 {
+		writeError(w, http.StatusNotFound, "no jokes found for category "+category)
+		return
+	}
+	writeJokeList(w, r, http.StatusOK, jokes)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// JSONFileStore is a JokeStore that keeps its data in a MemoryStore and
+// flushes the full contents to a JSON file on every mutation. It trades
+// write throughput for a human-editable, diffable corpus file.
+type JSONFileStore struct {
+	path string
+	mem  *MemoryStore
+}
+
+// NewJSONFileStore loads jokes from path, creating the file with the given
+// seed data if it does not yet exist.
+func NewJSONFileStore(path string, seed []Joke) (*JSONFileStore, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		s := &JSONFileStore{path: path, mem: NewMemoryStore(seed)}
+		if err := s.flush(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var jokes []Joke
+	if err := json.Unmarshal(data, &jokes); err != nil {
+		return nil, err
+	}
+	return &JSONFileStore{path: path, mem: NewMemoryStore(jokes)}, nil
+}
+
+func (s *JSONFileStore) flush() error {
+	jokes, err := s.mem.List()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(jokes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+func (s *JSONFileStore) List() ([]Joke, error) { return s.mem.List() }
+
+func (s *JSONFileStore) Get(id int) (Joke, error) { return s.mem.Get(id) }
+
+func (s *JSONFileStore) Random() (Joke, error) { return s.mem.Random() }
+
+func (s *JSONFileStore) ByCategory(category string) ([]Joke, error) {
+	return s.mem.ByCategory(category)
+}
+
+func (s *JSONFileStore) Add(j Joke) (Joke, error) {
+	added, err := s.mem.Add(j)
+	if err != nil {
+		return Joke{}, err
+	}
+	if err := s.flush(); err != nil {
+		return Joke{}, err
+	}
+	return added, nil
+}
+
+func (s *JSONFileStore) Update(id int, j Joke) (Joke, error) {
+	updated, err := s.mem.Update(id, j)
+	if err != nil {
+		return Joke{}, err
+	}
+	if err := s.flush(); err != nil {
+		return Joke{}, err
+	}
+	return updated, nil
+}
+
+func (s *JSONFileStore) Delete(id int) error {
+	if err := s.mem.Delete(id); err != nil {
+		return err
+	}
+	return s.flush()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// MemoryStore is an in-memory JokeStore backed by a map guarded by a mutex.
+// It is the default backend and the one used to seed the other backends.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	jokes  map[int]Joke
+	nextID int
+}
+
+// NewMemoryStore builds a MemoryStore pre-populated with the given jokes.
+func NewMemoryStore(seed []Joke) *MemoryStore {
+	s := &MemoryStore{jokes: make(map[int]Joke, len(seed))}
+	for _, j := range seed {
+		s.jokes[j.ID] = j
+		if j.ID >= s.nextID {
+			s.nextID = j.ID + 1
+		}
+	}
+	return s
+}
+
+func (s *MemoryStore) List() ([]Joke, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]Joke, 0, len(s.jokes))
+	for _, j := range s.jokes {
+		out = append(out, j)
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) Get(id int) (Joke, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	j, ok := s.jokes[id]
+	if !ok {
+		return Joke{}, ErrJokeNotFound
+	}
+	return j, nil
+}
+
+func (s *MemoryStore) Random() (Joke, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.jokes) == 0 {
+		return Joke{}, ErrJokeNotFound
+	}
+	ids := make([]int, 0, len(s.jokes))
+	for id := range s.jokes {
+		ids = append(ids, id)
+	}
+	return s.jokes[ids[rand.Intn(len(ids))]], nil
+}
+
+func (s *MemoryStore) Add(j Joke) (Joke, error) {
+	if err := validateJoke(j); err != nil {
+		return Joke{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j.ID = s.nextID
+	s.nextID++
+	s.jokes[j.ID] = j
+	return j, nil
+}
+
+func (s *MemoryStore) Update(id int, j Joke) (Joke, error) {
+	if err := validateJoke(j); err != nil {
+		return Joke{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jokes[id]; !ok {
+		return Joke{}, ErrJokeNotFound
+	}
+	j.ID = id
+	s.jokes[id] = j
+	return j, nil
+}
+
+func (s *MemoryStore) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jokes[id]; !ok {
+		return ErrJokeNotFound
+	}
+	delete(s.jokes, id)
+	return nil
+}
+
+func (s *MemoryStore) ByCategory(category string) ([]Joke, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []Joke
+	for _, j := range s.jokes {
+		if j.Category == category {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// RED-style HTTP handler metrics, registered on the default registerer so
+// they show up alongside the Go runtime collectors on /metrics.
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests processed, labeled by endpoint, method, and status code.",
+		},
+		[]string{"endpoint", "method", "code"},
+	)
+
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests in seconds.",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"endpoint", "method", "code"},
+	)
+
+	httpResponseSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_response_size_bytes",
+			Help:    "Size of HTTP responses in bytes.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 8),
+		},
+		[]string{"endpoint", "method", "code"},
+	)
+
+	httpRequestsInFlight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		},
+	)
+)
+
+// registerPrometheusMetrics registers the RED metrics and a build-info
+// collector so operators can correlate dashboards with the running binary.
+func registerPrometheusMetrics() {
+	prometheus.MustRegister(
+		httpRequestsTotal,
+		httpRequestDuration,
+		httpResponseSize,
+		httpRequestsInFlight,
+		translationRequestsTotal,
+		rateLimitHitsTotal,
+		apiKeyRequestsTotal,
+		collectors.NewBuildInfoCollector(),
+	)
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code and
+// byte count actually written, since neither is otherwise observable once
+// the handler chain has run.
+type statusRecorder struct {
+	http.ResponseWriter
+	status  int
+	written int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.written += n
+	return n, err
+}
+
+// promMetricsMiddleware records RED metrics for every request using the
+// actual status code and response size, then delegates the in-flight gauge
+// to promhttp's own InstrumentHandlerInFlight chain.
+func promMetricsMiddleware(next http.Handler) http.Handler {
+	instrumented := promhttp.InstrumentHandlerInFlight(httpRequestsInFlight, next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+
+		instrumented.ServeHTTP(rec, r)
+
+		endpoint := endpointLabel(r)
+		code := strconv.Itoa(rec.status)
+		elapsed := time.Since(start).Seconds()
+
+		httpRequestsTotal.WithLabelValues(endpoint, r.Method, code).Inc()
+		observeRequestDuration(r.Context(), endpoint, r.Method, code, elapsed)
+		httpResponseSize.WithLabelValues(endpoint, r.Method, code).Observe(float64(rec.written))
+	})
+}
+
+// endpointLabel prefers the matched route's path template (e.g. "/jokes/{id}")
+// over the raw URL path so per-id requests don't blow up metric cardinality.
+func endpointLabel(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return r.URL.Path
+}
+
+// metricsHandler exposes /metrics with the promhttp exposition-error counter
+// (mirroring the one added to client_golang in 0.9.4) wired through
+// HandlerOpts so failures while scraping are themselves observable.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+		Registry: prometheus.DefaultRegisterer,
+	})
+}
@@ -0,0 +1,90 @@
+package main
+
+import "database/sql"
+
+// SQLAPIKeyStore is an APIKeyStore backed by database/sql, sharing the same
+// driver/DSN conventions as SQLStore. The driver name is kept around to
+// rebind "?" placeholders into Postgres's "$1, $2, ..." form.
+type SQLAPIKeyStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLAPIKeyStore opens driverName at dsn and migrates the api_keys table.
+func NewSQLAPIKeyStore(driverName, dsn string) (*SQLAPIKeyStore, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	s := &SQLAPIKeyStore{db: db, driver: driverName}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// rebind delegates to rebindQuery using this store's driver.
+func (s *SQLAPIKeyStore) rebind(query string) string {
+	return rebindQuery(s.driver, query)
+}
+
+func (s *SQLAPIKeyStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id      TEXT PRIMARY KEY,
+			key     TEXT UNIQUE NOT NULL,
+			admin   BOOLEAN NOT NULL DEFAULT FALSE,
+			revoked BOOLEAN NOT NULL DEFAULT FALSE
+		)
+	`)
+	return err
+}
+
+func (s *SQLAPIKeyStore) Lookup(key string) (APIKey, error) {
+	var k APIKey
+	err := s.db.QueryRow(s.rebind(`SELECT id, key, admin, revoked FROM api_keys WHERE key = ?`), key).
+		Scan(&k.ID, &k.Key, &k.Admin, &k.Revoked)
+	if err == sql.ErrNoRows || (err == nil && k.Revoked) {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return APIKey{}, err
+	}
+	return k, nil
+}
+
+func (s *SQLAPIKeyStore) Create(id string, admin bool) (APIKey, error) {
+	k := APIKey{ID: id, Key: generateAPIKey(), Admin: admin}
+	_, err := s.db.Exec(s.rebind(`INSERT INTO api_keys (id, key, admin, revoked) VALUES (?, ?, ?, FALSE)`), k.ID, k.Key, k.Admin)
+	if err != nil {
+		return APIKey{}, err
+	}
+	return k, nil
+}
+
+func (s *SQLAPIKeyStore) Bootstrap(key APIKey) error {
+	_, err := s.db.Exec(
+		s.rebind(`INSERT INTO api_keys (id, key, admin, revoked) VALUES (?, ?, ?, FALSE) ON CONFLICT (key) DO NOTHING`),
+		key.ID, key.Key, key.Admin,
+	)
+	return err
+}
+
+func (s *SQLAPIKeyStore) Revoke(id string) error {
+	res, err := s.db.Exec(s.rebind(`UPDATE api_keys SET revoked = TRUE WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLStore is a JokeStore backed by database/sql. It works against both
+// Postgres and SQLite since it only relies on standard SQL and stores the
+// multi-language Content map as a JSON blob column. Postgres needs
+// positional "$1, $2, ..." placeholders and has no LastInsertId support, so
+// the driver name is kept around to rebind queries and choose the right
+// insert strategy.
+type SQLStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLStore opens driverName (e.g. "postgres" or "sqlite3") at dsn, runs
+// the schema migration if needed, and seeds the table when it is empty.
+func NewSQLStore(driverName, dsn string, seed []Joke) (*SQLStore, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	s := &SQLStore{db: db, driver: driverName}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	if err := s.seedIfEmpty(seed); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// rebind delegates to rebindQuery using this store's driver.
+func (s *SQLStore) rebind(query string) string {
+	return rebindQuery(s.driver, query)
+}
+
+func (s *SQLStore) migrate() error {
+	idColumn := "id       INTEGER PRIMARY KEY"
+	if s.driver == "postgres" {
+		// SERIAL so "RETURNING id" in insert has a sequence to draw from
+		// when the caller doesn't supply one.
+		idColumn = "id       SERIAL PRIMARY KEY"
+	}
+	_, err := s.db.Exec(fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS jokes (
+			%s,
+			content  TEXT NOT NULL,
+			category TEXT NOT NULL
+		)
+	`, idColumn))
+	return err
+}
+
+func (s *SQLStore) seedIfEmpty(seed []Joke) error {
+	var count int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM jokes`).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	for _, j := range seed {
+		if _, err := s.insert(j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) insert(j Joke) (Joke, error) {
+	content, err := json.Marshal(j.Content)
+	if err != nil {
+		return Joke{}, err
+	}
+
+	if s.driver == "postgres" {
+		// lib/pq doesn't implement LastInsertId, so ask Postgres to hand the
+		// assigned id straight back instead. The id column must be omitted
+		// entirely for new rows: SERIAL only applies its nextval() default
+		// when the column isn't part of the INSERT at all, not when it's
+		// bound to an explicit NULL, which would violate the column's
+		// NOT NULL constraint.
+		if j.ID == 0 {
+			row := s.db.QueryRow(
+				s.rebind(`INSERT INTO jokes (content, category) VALUES (?, ?) RETURNING id`),
+				content, j.Category,
+			)
+			if err := row.Scan(&j.ID); err != nil {
+				return Joke{}, err
+			}
+			return j, nil
+		}
+		row := s.db.QueryRow(
+			s.rebind(`INSERT INTO jokes (id, content, category) VALUES (?, ?, ?) RETURNING id`),
+			j.ID, content, j.Category,
+		)
+		if err := row.Scan(&j.ID); err != nil {
+			return Joke{}, err
+		}
+		return j, nil
+	}
+
+	// SQLite's INTEGER PRIMARY KEY only auto-assigns a rowid when the bound
+	// parameter is NULL, not when it's the literal integer 0, so new rows
+	// must bind nullIfZero(j.ID) rather than j.ID directly.
+	res, err := s.db.Exec(s.rebind(`INSERT INTO jokes (id, content, category) VALUES (?, ?, ?)`), nullIfZero(j.ID), content, j.Category)
+	if err != nil {
+		return Joke{}, err
+	}
+	if j.ID == 0 {
+		id, err := res.LastInsertId()
+		if err != nil {
+			return Joke{}, err
+		}
+		j.ID = int(id)
+	}
+	return j, nil
+}
+
+// nullIfZero maps a zero joke id to nil so SQLite's INTEGER PRIMARY KEY
+// rowid-aliasing auto-assigns an id for new rows; inserting the literal
+// value 0 would instead create a row with id 0.
+func nullIfZero(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func (s *SQLStore) scanJoke(row interface{ Scan(...any) error }) (Joke, error) {
+	var j Joke
+	var content []byte
+	if err := row.Scan(&j.ID, &content, &j.Category); err != nil {
+		return Joke{}, err
+	}
+	if err := json.Unmarshal(content, &j.Content); err != nil {
+		return Joke{}, err
+	}
+	return j, nil
+}
+
+func (s *SQLStore) List() ([]Joke, error) {
+	rows, err := s.db.Query(`SELECT id, content, category FROM jokes`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Joke
+	for rows.Next() {
+		j, err := s.scanJoke(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, j)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) Get(id int) (Joke, error) {
+	row := s.db.QueryRow(s.rebind(`SELECT id, content, category FROM jokes WHERE id = ?`), id)
+	j, err := s.scanJoke(row)
+	if err == sql.ErrNoRows {
+		return Joke{}, ErrJokeNotFound
+	}
+	return j, err
+}
+
+func (s *SQLStore) Random() (Joke, error) {
+	row := s.db.QueryRow(`SELECT id, content, category FROM jokes ORDER BY RANDOM() LIMIT 1`)
+	j, err := s.scanJoke(row)
+	if err == sql.ErrNoRows {
+		return Joke{}, ErrJokeNotFound
+	}
+	return j, err
+}
+
+func (s *SQLStore) ByCategory(category string) ([]Joke, error) {
+	rows, err := s.db.Query(s.rebind(`SELECT id, content, category FROM jokes WHERE category = ?`), category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Joke
+	for rows.Next() {
+		j, err := s.scanJoke(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, j)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLStore) Add(j Joke) (Joke, error) {
+	if err := validateJoke(j); err != nil {
+		return Joke{}, err
+	}
+	j.ID = 0
+	return s.insert(j)
+}
+
+func (s *SQLStore) Update(id int, j Joke) (Joke, error) {
+	if err := validateJoke(j); err != nil {
+		return Joke{}, err
+	}
+	content, err := json.Marshal(j.Content)
+	if err != nil {
+		return Joke{}, err
+	}
+	res, err := s.db.Exec(s.rebind(`UPDATE jokes SET content = ?, category = ? WHERE id = ?`), content, j.Category, id)
+	if err != nil {
+		return Joke{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Joke{}, err
+	}
+	if affected == 0 {
+		return Joke{}, ErrJokeNotFound
+	}
+	j.ID = id
+	return j, nil
+}
+
+func (s *SQLStore) Delete(id int) error {
+	res, err := s.db.Exec(s.rebind(`DELETE FROM jokes WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrJokeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrJokeNotFound is returned by a JokeStore when the requested joke id
+// does not exist.
+var ErrJokeNotFound = errors.New("joke not found")
+
+// ErrInvalidJoke is returned when a joke fails validation, e.g. an empty
+// Content map or a blank category.
+var ErrInvalidJoke = errors.New("invalid joke")
+
+// JokeStore is the persistence interface for joke data. It is implemented by
+// an in-memory map, a JSON file, and SQL-backed (Postgres/SQLite) stores so
+// the backend can be swapped via env vars without touching the handlers.
+type JokeStore interface {
+	// List returns every joke in the store.
+	List() ([]Joke, error)
+	// Get returns the joke with the given id, or ErrJokeNotFound.
+	Get(id int) (Joke, error)
+	// Random returns a single joke chosen at random.
+	Random() (Joke, error)
+	// Add inserts a new joke, assigning it an id, and returns the stored joke.
+	Add(j Joke) (Joke, error)
+	// Update replaces the joke with the given id and returns the stored joke.
+	Update(id int, j Joke) (Joke, error)
+	// Delete removes the joke with the given id.
+	Delete(id int) error
+	// ByCategory returns every joke matching the given category.
+	ByCategory(category string) ([]Joke, error)
+}
+
+// validateJoke enforces the minimal JSON schema for a joke: it must carry at
+// least one piece of content and a category.
+func validateJoke(j Joke) error {
+	if len(j.Content) == 0 {
+		return errors.New("content must contain at least one language")
+	}
+	for lang, text := range j.Content {
+		if lang == "" {
+			return errors.New("content language key must not be empty")
+		}
+		if text == "" {
+			return errors.New("content text for language " + lang + " must not be empty")
+		}
+	}
+	if j.Category == "" {
+		return errors.New("category must not be empty")
+	}
+	return nil
+}
+
+// rebindQuery rewrites "?" placeholders into Postgres's positional
+// "$1, $2, ..." form when driver is "postgres"; every other driver (e.g.
+// "sqlite3") already speaks "?" natively. Shared by SQLStore and
+// SQLAPIKeyStore so both SQL-backed stores work against either backend.
+func rebindQuery(driver, query string) string {
+	if driver != "postgres" {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateJoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		joke    Joke
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			joke:    Joke{Content: map[string]string{"en": "knock knock"}, Category: "pun"},
+			wantErr: false,
+		},
+		{
+			name:    "empty content",
+			joke:    Joke{Content: map[string]string{}, Category: "pun"},
+			wantErr: true,
+		},
+		{
+			name:    "blank language key",
+			joke:    Joke{Content: map[string]string{"": "knock knock"}, Category: "pun"},
+			wantErr: true,
+		},
+		{
+			name:    "blank content text",
+			joke:    Joke{Content: map[string]string{"en": ""}, Category: "pun"},
+			wantErr: true,
+		},
+		{
+			name:    "blank category",
+			joke:    Joke{Content: map[string]string{"en": "knock knock"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJoke(tt.joke)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateJoke() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreNotFound(t *testing.T) {
+	s := NewMemoryStore(nil)
+
+	if _, err := s.Get(1); !errors.Is(err, ErrJokeNotFound) {
+		t.Errorf("Get() error = %v, want ErrJokeNotFound", err)
+	}
+	if _, err := s.Update(1, Joke{Content: map[string]string{"en": "x"}, Category: "c"}); !errors.Is(err, ErrJokeNotFound) {
+		t.Errorf("Update() error = %v, want ErrJokeNotFound", err)
+	}
+	if err := s.Delete(1); !errors.Is(err, ErrJokeNotFound) {
+		t.Errorf("Delete() error = %v, want ErrJokeNotFound", err)
+	}
+	if _, err := s.Random(); !errors.Is(err, ErrJokeNotFound) {
+		t.Errorf("Random() error = %v, want ErrJokeNotFound", err)
+	}
+}
+
+func TestMemoryStoreAddGetUpdateDelete(t *testing.T) {
+	s := NewMemoryStore(nil)
+
+	created, err := s.Add(Joke{Content: map[string]string{"en": "why did the chicken cross the road"}, Category: "classic"})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	got, err := s.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Category != "classic" {
+		t.Errorf("Get() category = %q, want %q", got.Category, "classic")
+	}
+
+	updated, err := s.Update(created.ID, Joke{Content: map[string]string{"en": "updated"}, Category: "updated"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Category != "updated" {
+		t.Errorf("Update() category = %q, want %q", updated.Category, "updated")
+	}
+
+	if err := s.Delete(created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(created.ID); !errors.Is(err, ErrJokeNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrJokeNotFound", err)
+	}
+}
+
+func TestMemoryStoreAddRejectsInvalidJoke(t *testing.T) {
+	s := NewMemoryStore(nil)
+	if _, err := s.Add(Joke{}); err == nil {
+		t.Fatal("Add() with empty joke should have failed validation")
+	}
+}
+
+func TestMemoryStoreByCategory(t *testing.T) {
+	s := NewMemoryStore([]Joke{
+		{ID: 1, Content: map[string]string{"en": "a"}, Category: "pun"},
+		{ID: 2, Content: map[string]string{"en": "b"}, Category: "science"},
+	})
+
+	got, err := s.ByCategory("pun")
+	if err != nil {
+		t.Fatalf("ByCategory() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("ByCategory() = %+v, want single joke with id 1", got)
+	}
+
+	if got, _ := s.ByCategory("missing"); len(got) != 0 {
+		t.Errorf("ByCategory() for unknown category = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serviceVersion = "0.1.0"
+
+// tracer is the package-wide tracer used by the HTTP tracing middleware.
+var tracer trace.Tracer
+
+// initTracing configures an OTLP trace exporter (gRPC by default, HTTP when
+// OTEL_EXPORTER_OTLP_PROTOCOL=http/protobuf) behind a batch span processor,
+// tagged with a resource describing this service. Call it once at startup
+// alongside initMetrics, and call the returned shutdown func on exit.
+func initTracing(ctx context.Context) (func(context.Context) error, error) {
+	exporter, err := newTraceExporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("joke-api"),
+			semconv.ServiceVersion(serviceVersion),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, // W3C traceparent/tracestate
+		propagation.Baggage{},
+	))
+
+	tracer = provider.Tracer("joke-api")
+
+	return provider.Shutdown, nil
+}
+
+// newTraceExporter picks gRPC or HTTP based on OTEL_EXPORTER_OTLP_PROTOCOL,
+// matching the convention used by every other OTLP-based exporter.
+func newTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL") == "http/protobuf" {
+		return otlptrace.New(ctx, otlptracehttp.NewClient())
+	}
+	return otlptrace.New(ctx, otlptracegrpc.NewClient())
+}
+
+// tracingMiddleware extracts the incoming W3C trace context, starts a server
+// span per route, records HTTP semantic-convention attributes, and injects
+// the resulting context into the request so downstream calls propagate it.
+// It also stamps the duration histogram with an exemplar carrying the trace
+// ID so Grafana can jump from a latency bucket straight to the trace.
+func tracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		endpoint := endpointLabel(r)
+		ctx, span := tracer.Start(ctx, endpoint, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+
+		span.SetAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+			attribute.String("http.route", endpoint),
+		)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rec.status))
+	})
+}
+
+// observeRequestDuration records a sample on http_request_duration_seconds,
+// attaching the active span's trace ID as an exemplar so Grafana can jump
+// from a latency bucket straight to the trace that produced it.
+func observeRequestDuration(ctx context.Context, endpoint, method, code string, seconds float64) {
+	obs := httpRequestDuration.WithLabelValues(endpoint, method, code)
+
+	traceID := trace.SpanContextFromContext(ctx).TraceID()
+	exemplarObs, ok := obs.(prometheus.ExemplarObserver)
+	if !ok || !traceID.IsValid() {
+		obs.Observe(seconds)
+		return
+	}
+	exemplarObs.ObserveWithExemplar(seconds, prometheus.Labels{"trace_id": traceID.String()})
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// translationRequestsTotal tracks translation provider usage so operators can
+// see cost and cache-hit rates per provider/language.
+var translationRequestsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "translation_requests_total",
+		Help: "Total number of translation lookups, labeled by provider, language, and cache hit status.",
+	},
+	[]string{"provider", "lang", "cache_hit"},
+)
+
+// Translator resolves a joke's text into a language it doesn't already have
+// stored content for. Implementations are invoked on-demand from
+// getRandomJoke/getJoke when a requested lang is missing.
+type Translator interface {
+	// Name identifies the provider for the translation_requests_total metric.
+	Name() string
+	// Translate returns text translated from srcLang into dstLang.
+	Translate(ctx context.Context, text, srcLang, dstLang string) (string, error)
+}
+
+// noopTranslator never translates; it exists so installations without a
+// translation API key still get consistent cache_hit=false accounting
+// instead of a nil Translator special case.
+type noopTranslator struct{}
+
+func (noopTranslator) Name() string { return "noop" }
+
+func (noopTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	return "", fmt.Errorf("no translation provider configured")
+}
+
+// LibreTranslateClient talks to a self-hosted or public LibreTranslate
+// instance (https://libretranslate.com/docs).
+type LibreTranslateClient struct {
+	BaseURL string
+	APIKey  string
+	client  *http.Client
+}
+
+// NewLibreTranslateClient builds a client against baseURL (e.g.
+// "https://libretranslate.com"), authenticating with apiKey when non-empty.
+func NewLibreTranslateClient(baseURL, apiKey string) *LibreTranslateClient {
+	return &LibreTranslateClient{BaseURL: baseURL, APIKey: apiKey, client: http.DefaultClient}
+}
+
+func (c *LibreTranslateClient) Name() string { return "libretranslate" }
+
+func (c *LibreTranslateClient) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"q":       text,
+		"source":  srcLang,
+		"target":  dstLang,
+		"format":  "text",
+		"api_key": c.APIKey,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("libretranslate: unexpected status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.TranslatedText, nil
+}
+
+// DeepLClient talks to the DeepL API (https://www.deepl.com/docs-api).
+type DeepLClient struct {
+	BaseURL string
+	APIKey  string
+	client  *http.Client
+}
+
+// NewDeepLClient builds a client authenticated with apiKey. baseURL should be
+// DeepL's free or pro API endpoint.
+func NewDeepLClient(baseURL, apiKey string) *DeepLClient {
+	return &DeepLClient{BaseURL: baseURL, APIKey: apiKey, client: http.DefaultClient}
+}
+
+func (c *DeepLClient) Name() string { return "deepl" }
+
+func (c *DeepLClient) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"text":        []string{text},
+		"source_lang": srcLang,
+		"target_lang": dstLang,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v2/translate", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+c.APIKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("deepl: unexpected status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if len(result.Translations) == 0 {
+		return "", fmt.Errorf("deepl: empty response")
+	}
+	return result.Translations[0].Text, nil
+}
+
+// newTranslator selects a Translator based on the TRANSLATE_PROVIDER env var.
+// Supported values are "libretranslate", "deepl", and "noop" (default).
+func newTranslator() Translator {
+	switch provider := os.Getenv("TRANSLATE_PROVIDER"); provider {
+	case "libretranslate":
+		return NewLibreTranslateClient(os.Getenv("LIBRETRANSLATE_URL"), os.Getenv("LIBRETRANSLATE_API_KEY"))
+	case "deepl":
+		return NewDeepLClient(os.Getenv("DEEPL_API_URL"), os.Getenv("DEEPL_API_KEY"))
+	default:
+		return noopTranslator{}
+	}
+}
+
+// resolveJokeText returns joke's text in lang, translating and caching back
+// into the store on a miss. It records translation_requests_total regardless
+// of outcome so operators can see provider cost/hit rates.
+func (a *jokeAPI) resolveJokeText(ctx context.Context, joke Joke, lang string) (string, error) {
+	if text, ok := joke.Content[lang]; ok {
+		translationRequestsTotal.WithLabelValues(a.translator.Name(), lang, "true").Inc()
+		return text, nil
+	}
+
+	source, ok := joke.Content["en"]
+	if !ok {
+		return "", fmt.Errorf("joke %d has no English source to translate from", joke.ID)
+	}
+
+	translated, err := a.translator.Translate(ctx, source, "en", lang)
+	translationRequestsTotal.WithLabelValues(a.translator.Name(), lang, "false").Inc()
+	if err != nil {
+		return "", err
+	}
+
+	if joke.Content == nil {
+		joke.Content = map[string]string{}
+	}
+	joke.Content[lang] = translated
+	if _, err := a.store.Update(joke.ID, joke); err != nil {
+		return translated, nil // translation succeeded even if caching it didn't
+	}
+	return translated, nil
+}
+
+// resolveJokeTextOrFallback wraps resolveJokeText with the fallback behavior
+// shared by every transport: if resolution fails (e.g. the joke has no
+// English source to translate from, or the translator errors), it falls back
+// to whatever language the joke actually has content for instead of
+// propagating the error, reporting that language as effective.
+func (a *jokeAPI) resolveJokeTextOrFallback(ctx context.Context, joke Joke, lang string) (text, effectiveLang string) {
+	text, err := a.resolveJokeText(ctx, joke, lang)
+	if err == nil {
+		return text, lang
+	}
+	if text, ok := joke.Content["en"]; ok {
+		return text, "en"
+	}
+	if text, lang, ok := anyJokeContent(joke); ok {
+		return text, lang
+	}
+	return "", ""
+}
+
+// anyJokeContent returns an arbitrary non-empty language/text pair from
+// joke's Content map, picked deterministically by sorting language codes, so
+// callers always have something to fall back to even when the joke has no
+// English entry.
+func anyJokeContent(joke Joke) (text, lang string, ok bool) {
+	langs := make([]string, 0, len(joke.Content))
+	for l := range joke.Content {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	for _, l := range langs {
+		if joke.Content[l] != "" {
+			return joke.Content[l], l, true
+		}
+	}
+	return "", "", false
+}
+
+// knownLanguages returns the union of the built-in supported languages and
+// any language codes discovered in the store's jokes.
+func (a *jokeAPI) knownLanguages() ([]string, error) {
+	jokes, err := a.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	langs := map[string]bool{}
+	for lang := range supportedLangs {
+		langs[lang] = true
+	}
+	for _, j := range jokes {
+		for lang := range j.Content {
+			langs[lang] = true
+		}
+	}
+
+	out := make([]string, 0, len(langs))
+	for lang := range langs {
+		out = append(out, lang)
+	}
+	return out, nil
+}
+
+// getLanguages handles GET /languages.
+func (a *jokeAPI) getLanguages(w http.ResponseWriter, r *http.Request) {
+	langs, err := a.knownLanguages()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not list languages")
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Languages []string `json:"languages"`
+	}{Languages: langs})
+}
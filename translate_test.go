@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// stubTranslator returns a fixed translation and counts calls, so tests can
+// assert whether resolveJokeText actually invoked the translator.
+type stubTranslator struct {
+	calls int
+	err   error
+}
+
+func (s *stubTranslator) Name() string { return "stub" }
+
+func (s *stubTranslator) Translate(ctx context.Context, text, srcLang, dstLang string) (string, error) {
+	s.calls++
+	if s.err != nil {
+		return "", s.err
+	}
+	return "translated:" + text, nil
+}
+
+func TestResolveJokeTextCacheHit(t *testing.T) {
+	stub := &stubTranslator{}
+	api := &jokeAPI{store: NewMemoryStore(nil), translator: stub}
+
+	joke := Joke{ID: 1, Content: map[string]string{"en": "hello", "es": "hola"}, Category: "pun"}
+
+	text, err := api.resolveJokeText(context.Background(), joke, "es")
+	if err != nil {
+		t.Fatalf("resolveJokeText() error = %v", err)
+	}
+	if text != "hola" {
+		t.Errorf("resolveJokeText() = %q, want stored translation %q", text, "hola")
+	}
+	if stub.calls != 0 {
+		t.Errorf("translator was called %d times on a cache hit, want 0", stub.calls)
+	}
+}
+
+func TestResolveJokeTextCacheMissTranslatesAndCaches(t *testing.T) {
+	stub := &stubTranslator{}
+	store := NewMemoryStore([]Joke{{ID: 1, Content: map[string]string{"en": "hello"}, Category: "pun"}})
+	api := &jokeAPI{store: store, translator: stub}
+
+	joke, err := store.Get(1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	text, err := api.resolveJokeText(context.Background(), joke, "fr")
+	if err != nil {
+		t.Fatalf("resolveJokeText() error = %v", err)
+	}
+	if text != "translated:hello" {
+		t.Errorf("resolveJokeText() = %q, want %q", text, "translated:hello")
+	}
+	if stub.calls != 1 {
+		t.Errorf("translator was called %d times, want 1", stub.calls)
+	}
+
+	// The translation should have been cached back into the store.
+	cached, err := store.Get(1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if cached.Content["fr"] != "translated:hello" {
+		t.Errorf("cached content[fr] = %q, want %q", cached.Content["fr"], "translated:hello")
+	}
+}
+
+func TestResolveJokeTextNoEnglishSource(t *testing.T) {
+	api := &jokeAPI{store: NewMemoryStore(nil), translator: &stubTranslator{}}
+	joke := Joke{ID: 1, Content: map[string]string{"es": "hola"}, Category: "pun"}
+
+	if _, err := api.resolveJokeText(context.Background(), joke, "fr"); err == nil {
+		t.Fatal("resolveJokeText() with no English source should have failed")
+	}
+}
+
+func TestResolveJokeTextTranslatorError(t *testing.T) {
+	stub := &stubTranslator{err: fmt.Errorf("provider unavailable")}
+	api := &jokeAPI{store: NewMemoryStore(nil), translator: stub}
+	joke := Joke{ID: 1, Content: map[string]string{"en": "hello"}, Category: "pun"}
+
+	if _, err := api.resolveJokeText(context.Background(), joke, "fr"); err == nil {
+		t.Fatal("resolveJokeText() should surface the translator error")
+	}
+}
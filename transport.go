@@ -0,0 +1,64 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	jokev1 "github.com/vikaskrishnia/joke-api/proto/jokev1"
+	"google.golang.org/protobuf/proto"
+)
+
+// wantsProtobuf reports whether the client asked for protobuf/protojson via
+// the Accept header, so the same handler can serve JSON or proto clients.
+func wantsProtobuf(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/protobuf") || strings.Contains(accept, "application/x-protobuf")
+}
+
+// writeJoke writes a single Joke, choosing JSON or binary protobuf based on the
+// request's Accept header.
+func writeJoke(w http.ResponseWriter, r *http.Request, status int, j Joke) {
+	if !wantsProtobuf(r) {
+		writeJSON(w, status, j)
+		return
+	}
+	writeProto(w, status, jokeToProto(j))
+}
+
+// writeJokeResponse writes a JokeResponse (the /joke payload), choosing JSON
+// or binary protobuf based on the request's Accept header.
+func writeJokeResponse(w http.ResponseWriter, r *http.Request, status int, resp JokeResponse) {
+	if !wantsProtobuf(r) {
+		writeJSON(w, status, resp)
+		return
+	}
+	writeProto(w, status, &jokev1.JokeResponse{Joke: resp.Joke, Language: resp.Language})
+}
+
+// writeJokeList writes a slice of Jokes, choosing JSON or binary protobuf
+// based on the request's Accept header.
+func writeJokeList(w http.ResponseWriter, r *http.Request, status int, jokes []Joke) {
+	if !wantsProtobuf(r) {
+		writeJSON(w, status, jokes)
+		return
+	}
+	out := make([]*jokev1.Joke, 0, len(jokes))
+	for _, j := range jokes {
+		out = append(out, jokeToProto(j))
+	}
+	writeProto(w, status, &jokev1.ListJokesResponse{Jokes: out})
+}
+
+// writeProto writes msg as binary protobuf wire format, matching what a
+// client sending "Accept: application/protobuf" or "application/x-protobuf"
+// (see wantsProtobuf) actually expects to parse.
+func writeProto(w http.ResponseWriter, status int, msg proto.Message) {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "could not marshal protobuf response")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(status)
+	w.Write(body)
+}